@@ -1,7 +0,0 @@
-package mockgen
-
-type IUserRepo interface {
-	GetUserByID(id int) (*User, error)
-	Insert(user User) error
-	Update(id int, user User) error
-}
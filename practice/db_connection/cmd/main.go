@@ -29,13 +29,16 @@ func main() {
 	dsn := "appuser:apppassword@tcp(localhost:3306)/practice_db?parseTime=true"
 	var ch chan struct{} = make(chan struct{})
 	config := db_connection.PoolConfig{
-		MaxOpenConns:    25,
-		MaxIdleConns:    1,
-		ConnMaxLifetime: 1 * time.Second,
-		ConnMaxIdleTime: 1 * time.Second,
+		MaxOpenConns:      25,
+		MaxIdleConns:      1,
+		ConnMaxLifetime:   1 * time.Second,
+		ConnMaxIdleTime:   1 * time.Second,
+		ConnectRetries:    5,
+		ConnectBackoff:    500 * time.Millisecond,
+		ConnectBackoffMax: 10 * time.Second,
 	}
 
-	pool, err := db_connection.NewDBPool(dsn, config)
+	pool, err := db_connection.NewDBPool(context.Background(), dsn, config)
 	if err != nil {
 		log.Fatalf("Failed to create database pool: %v", err)
 	}
@@ -44,14 +47,17 @@ func main() {
 	fmt.Println("=== Database Connection Pool with Client Abort Simulation ===")
 	pool.PrintStats()
 	go simulateClientAborts(pool, ch)
-	ticker := time.NewTicker(5 * time.Second)
+
+	statsCtx, cancelStats := context.WithCancel(context.Background())
+	defer cancelStats()
+	stats := pool.WatchStats(statsCtx, 5*time.Second)
 	for {
 		select {
 		case <-ch:
 			fmt.Println("Client abort simulation finished")
 			return
-		case <-ticker.C:
-			pool.PrintStats()
+		case s := <-stats:
+			log.Printf("Open Connections: %d, In Use: %d, Idle: %d, Wait Count: %d", s.OpenConnections, s.InUse, s.Idle, s.WaitCount)
 		}
 	}
 }
@@ -0,0 +1,266 @@
+// Package dbtest provides an in-memory fake MySQL backend for exercising
+// db_connection.DBPool methods without a live MySQL container, in the
+// spirit of Cloud Spanner's MockedSpannerInMemTestServer.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark1002/practice/db_connection"
+)
+
+var driverSeq int64
+
+// Rows is a builder for a scripted result set returned from a query.
+type Rows struct {
+	cols []string
+	data [][]driver.Value
+}
+
+// NewRows starts a scripted result set with the given column names.
+func NewRows(cols ...string) *Rows {
+	return &Rows{cols: cols}
+}
+
+// AddRow appends a row of values to the scripted result set.
+func (r *Rows) AddRow(values ...driver.Value) *Rows {
+	r.data = append(r.data, values)
+	return r
+}
+
+// Result is a scripted driver.Result for an Exec call.
+type Result struct {
+	LastInsertID  int64
+	RowsAffectedN int64
+}
+
+func (r Result) LastInsertId() (int64, error) { return r.LastInsertID, nil }
+func (r Result) RowsAffected() (int64, error) { return r.RowsAffectedN, nil }
+
+type queryScript struct {
+	pattern *regexp.Regexp
+	rows    *Rows
+	err     error
+	delay   time.Duration
+}
+
+type execScript struct {
+	pattern *regexp.Regexp
+	result  driver.Result
+	err     error
+}
+
+// Controller lets a test script the fake backend's behavior: which rows or
+// results a SQL statement produces, and errors to inject.
+type Controller struct {
+	mu       sync.Mutex
+	queries  []*queryScript
+	execs    []*execScript
+	failNext []error
+}
+
+// ExpectQuery registers the rows returned for a query matching pattern.
+// Returned calls of After() make the match sleep, so that context
+// cancellation during the sleep can be exercised deterministically.
+func (c *Controller) ExpectQuery(pattern string, rows *Rows) *queryScript {
+	s := &queryScript{pattern: regexp.MustCompile(pattern), rows: rows}
+	c.mu.Lock()
+	c.queries = append(c.queries, s)
+	c.mu.Unlock()
+	return s
+}
+
+// After marks a scripted query as slow: the fake conn sleeps for d,
+// respecting context cancellation, before returning rows.
+func (s *queryScript) After(d time.Duration) *queryScript {
+	s.delay = d
+	return s
+}
+
+// ExpectExec registers the result returned for an exec matching pattern.
+func (c *Controller) ExpectExec(pattern string, result driver.Result) *execScript {
+	s := &execScript{pattern: regexp.MustCompile(pattern), result: result}
+	c.mu.Lock()
+	c.execs = append(c.execs, s)
+	c.mu.Unlock()
+	return s
+}
+
+// FailNextWith makes the next query or exec call - regardless of which
+// statement it is - fail with err. Useful for injecting a one-off error
+// such as a scripted MySQL deadlock.
+func (c *Controller) FailNextWith(err error) {
+	c.mu.Lock()
+	c.failNext = append(c.failNext, err)
+	c.mu.Unlock()
+}
+
+func (c *Controller) popFailure() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.failNext) == 0 {
+		return nil
+	}
+	err := c.failNext[0]
+	c.failNext = c.failNext[1:]
+	return err
+}
+
+func (c *Controller) matchQuery(query string) (*queryScript, error) {
+	if err := c.popFailure(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.queries {
+		if s.pattern.MatchString(query) {
+			return s, s.err
+		}
+	}
+	return nil, fmt.Errorf("dbtest: no ExpectQuery scripted for %q", query)
+}
+
+func (c *Controller) matchExec(query string) (*execScript, error) {
+	if err := c.popFailure(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.execs {
+		if s.pattern.MatchString(query) {
+			return s, s.err
+		}
+	}
+	return nil, fmt.Errorf("dbtest: no ExpectExec scripted for %q", query)
+}
+
+// NewInMemPool returns a *db_connection.DBPool backed by a registered
+// database/sql driver whose behavior is fully controlled by the returned
+// Controller, so DBPool methods can be unit-tested without Docker.
+func NewInMemPool(t testing.TB) (*db_connection.DBPool, *Controller) {
+	t.Helper()
+
+	ctrl := &Controller{}
+	name := fmt.Sprintf("dbtest-%d", atomic.AddInt64(&driverSeq, 1))
+	sql.Register(name, &fakeDriver{ctrl: ctrl})
+
+	db, err := sql.Open(name, "dbtest")
+	if err != nil {
+		t.Fatalf("dbtest: failed to open fake database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return &db_connection.DBPool{DB: db}, ctrl
+}
+
+type fakeDriver struct {
+	ctrl *Controller
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{ctrl: d.ctrl}, nil
+}
+
+type fakeConn struct {
+	ctrl *Controller
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return &fakeTx{}, nil
+}
+
+func (c *fakeConn) Ping(ctx context.Context) error { return nil }
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	script, err := c.ctrl.matchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if script.delay > 0 {
+		select {
+		case <-time.After(script.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return newFakeRows(script.rows), nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	script, err := c.ctrl.matchExec(query)
+	if err != nil {
+		return nil, err
+	}
+	return script.result, nil
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, valuesToNamed(args))
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, valuesToNamed(args))
+}
+
+func valuesToNamed(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+type fakeTx struct{}
+
+func (t *fakeTx) Commit() error   { return nil }
+func (t *fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	cols []string
+	data [][]driver.Value
+	pos  int
+}
+
+func newFakeRows(r *Rows) *fakeRows {
+	if r == nil {
+		return &fakeRows{}
+	}
+	return &fakeRows{cols: r.cols, data: r.data}
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.pos])
+	r.pos++
+	return nil
+}
@@ -0,0 +1,37 @@
+package db_connection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark1002/practice/db_connection/dbtest"
+)
+
+func TestGetUserByIDScansScriptedRow(t *testing.T) {
+	pool, ctrl := dbtest.NewInMemPool(t)
+	ctrl.ExpectQuery("SELECT id, username, email", dbtest.NewRows("id", "username", "email", "created_at", "updated_at").
+		AddRow(int64(1), "alice", "alice@example.com", "2024-01-01", "2024-01-01"))
+
+	user, err := pool.GetUserByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetUserByID returned unexpected error: %v", err)
+	}
+	if user.Username != "alice" || user.Email != "alice@example.com" {
+		t.Fatalf("got user %+v, want username=alice email=alice@example.com", user)
+	}
+}
+
+func TestSimulateClientAbortTimesOutOnSlowQuery(t *testing.T) {
+	pool, ctrl := dbtest.NewInMemPool(t)
+	ctrl.ExpectQuery("SELECT SLEEP", dbtest.NewRows()).After(50 * time.Millisecond)
+
+	err := pool.SimulateClientAbort(context.Background(), 200*time.Millisecond, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected SimulateClientAbort to return an error when the client aborts")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a context.DeadlineExceeded-wrapped error, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -20,9 +21,24 @@ type PoolConfig struct {
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
+
+	// ConnectRetries is the number of additional attempts NewDBPool makes
+	// to open and ping the database before giving up. A value of 0 means
+	// a single attempt with no retries.
+	ConnectRetries int
+	// ConnectBackoff is the base delay used for the exponential backoff
+	// between connect attempts.
+	ConnectBackoff time.Duration
+	// ConnectBackoffMax caps the backoff delay so it doesn't grow unbounded.
+	ConnectBackoffMax time.Duration
 }
 
-func NewDBPool(dsn string, config PoolConfig) (*DBPool, error) {
+// NewDBPool opens a MySQL connection pool and pings it, retrying with
+// exponential backoff and jitter up to config.ConnectRetries times. This
+// makes callers resilient to brief MySQL restarts or cold-start races
+// during container startup. The provided ctx bounds the entire retry loop;
+// canceling it aborts any in-progress backoff sleep.
+func NewDBPool(ctx context.Context, dsn string, config PoolConfig) (*DBPool, error) {
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
@@ -34,9 +50,9 @@ func NewDBPool(dsn string, config PoolConfig) (*DBPool, error) {
 	db.SetConnMaxLifetime(config.ConnMaxLifetime)
 	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
 
-	// Test the connection
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	if err := pingWithRetry(ctx, db, config); err != nil {
+		db.Close()
+		return nil, err
 	}
 
 	log.Printf("Database connection pool configured:")
@@ -48,6 +64,52 @@ func NewDBPool(dsn string, config PoolConfig) (*DBPool, error) {
 	return &DBPool{DB: db}, nil
 }
 
+// pingWithRetry pings db, retrying with exponential backoff and jitter
+// until it succeeds, config.ConnectRetries is exhausted, or ctx is done.
+func pingWithRetry(ctx context.Context, db *sql.DB, config PoolConfig) error {
+	var lastErr error
+	for attempt := 0; attempt <= config.ConnectRetries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("connect to database canceled: %w", err)
+		}
+
+		lastErr = db.PingContext(ctx)
+		if lastErr == nil {
+			return nil
+		}
+
+		log.Printf("Ping attempt %d/%d failed: %v", attempt+1, config.ConnectRetries+1, lastErr)
+		if attempt == config.ConnectRetries {
+			break
+		}
+
+		delay := backoffDelay(attempt, config.ConnectBackoff, config.ConnectBackoffMax)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("connect to database canceled: %w", ctx.Err())
+		}
+	}
+
+	return fmt.Errorf("failed to ping database after %d attempts: %w", config.ConnectRetries+1, lastErr)
+}
+
+// backoffDelay computes min(backoff*2^attempt + jitter, max).
+func backoffDelay(attempt int, backoff, max time.Duration) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+
+	delay := backoff * time.Duration(1<<uint(attempt))
+	jitter := time.Duration(rand.Int63n(int64(backoff)))
+	delay += jitter
+
+	if max > 0 && delay > max {
+		delay = max
+	}
+	return delay
+}
+
 func (p *DBPool) Close() error {
 	return p.DB.Close()
 }
@@ -1,102 +1,101 @@
 package db_connection
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-)
-
-type User struct {
-	ID        int    `json:"id"`
-	Username  string `json:"username"`
-	Email     string `json:"email"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-}
-
-type Order struct {
-	ID          int     `json:"id"`
-	UserID      int     `json:"user_id"`
-	ProductName string  `json:"product_name"`
-	Quantity    int     `json:"quantity"`
-	Price       float64 `json:"price"`
-	Status      string  `json:"status"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
-}
 
-func (p *DBPool) GetAllUsers() ([]User, error) {
-	query := "SELECT id, username, email, created_at, updated_at FROM users ORDER BY id"
-	rows, err := p.DB.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query users: %w", err)
-	}
-	defer rows.Close()
+	"github.com/mark1002/practice/models"
+)
 
-	var users []User
-	for rows.Next() {
-		var user User
-		err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.CreatedAt, &user.UpdatedAt)
+func (p *DBPool) GetAllUsers(ctx context.Context) ([]models.User, error) {
+	var users []models.User
+	err := p.Do(ctx, DefaultRetryPolicy(), func(ctx context.Context) error {
+		users = nil
+		query := "SELECT id, username, email, created_at, updated_at FROM users ORDER BY id"
+		rows, err := p.DB.QueryContext(ctx, query)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan user: %w", err)
+			return fmt.Errorf("failed to query users: %w", err)
 		}
-		users = append(users, user)
-	}
+		defer rows.Close()
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("row iteration error: %w", err)
-	}
+		for rows.Next() {
+			var user models.User
+			if err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
+				return fmt.Errorf("failed to scan user: %w", err)
+			}
+			users = append(users, user)
+		}
 
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("row iteration error: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return users, nil
 }
 
-func (p *DBPool) GetUserByID(id int) (*User, error) {
-	query := "SELECT id, username, email, created_at, updated_at FROM users WHERE id = ?"
-	row := p.DB.QueryRow(query, id)
+func (p *DBPool) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	var user models.User
+	err := p.Do(ctx, DefaultRetryPolicy(), func(ctx context.Context) error {
+		query := "SELECT id, username, email, created_at, updated_at FROM users WHERE id = ?"
+		row := p.DB.QueryRowContext(ctx, query, id)
 
-	var user User
-	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.CreatedAt, &user.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user with id %d not found", id)
+		if err := row.Scan(&user.ID, &user.Username, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("user with id %d not found", id)
+			}
+			return fmt.Errorf("failed to scan user: %w", err)
 		}
-		return nil, fmt.Errorf("failed to scan user: %w", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-
 	return &user, nil
 }
 
-func (p *DBPool) GetOrdersByUserID(userID int) ([]Order, error) {
-	query := `SELECT id, user_id, product_name, quantity, price, status, created_at, updated_at 
-			  FROM orders WHERE user_id = ? ORDER BY created_at DESC`
-	rows, err := p.DB.Query(query, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query orders: %w", err)
-	}
-	defer rows.Close()
-
-	var orders []Order
-	for rows.Next() {
-		var order Order
-		err := rows.Scan(&order.ID, &order.UserID, &order.ProductName, &order.Quantity,
-			&order.Price, &order.Status, &order.CreatedAt, &order.UpdatedAt)
+func (p *DBPool) GetOrdersByUserID(ctx context.Context, userID int) ([]models.Order, error) {
+	var orders []models.Order
+	err := p.Do(ctx, DefaultRetryPolicy(), func(ctx context.Context) error {
+		orders = nil
+		query := `SELECT id, user_id, product_name, quantity, price, status, created_at, updated_at
+				  FROM orders WHERE user_id = ? ORDER BY created_at DESC`
+		rows, err := p.DB.QueryContext(ctx, query, userID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan order: %w", err)
+			return fmt.Errorf("failed to query orders: %w", err)
 		}
-		orders = append(orders, order)
-	}
+		defer rows.Close()
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("row iteration error: %w", err)
-	}
+		for rows.Next() {
+			var order models.Order
+			if err := rows.Scan(&order.ID, &order.UserID, &order.ProductName, &order.Quantity,
+				&order.Price, &order.Status, &order.CreatedAt, &order.UpdatedAt); err != nil {
+				return fmt.Errorf("failed to scan order: %w", err)
+			}
+			orders = append(orders, order)
+		}
 
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("row iteration error: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	return orders, nil
 }
 
-func (p *DBPool) CreateUser(username, email string) error {
-	query := "INSERT INTO users (username, email) VALUES (?, ?)"
-	_, err := p.DB.Exec(query, username, email)
-	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
-	}
-	return nil
+func (p *DBPool) CreateUser(ctx context.Context, username, email string) error {
+	return p.InTx(ctx, nil, func(tx *sql.Tx) error {
+		query := "INSERT INTO users (username, email) VALUES (?, ?)"
+		if _, err := tx.ExecContext(ctx, query, username, email); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		return nil
+	})
 }
@@ -0,0 +1,121 @@
+package db_connection
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/mark1002/practice/mockgen"
+	"github.com/mark1002/practice/models"
+)
+
+var _ mockgen.IUserRepo = (*MySQLUserRepo)(nil)
+
+// MySQLUserRepo implements mockgen.IUserRepo against a DBPool, caching
+// prepared statements per query so repeated calls don't re-prepare.
+type MySQLUserRepo struct {
+	pool *DBPool
+
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+}
+
+func NewMySQLUserRepo(pool *DBPool) *MySQLUserRepo {
+	return &MySQLUserRepo{
+		pool:  pool,
+		stmts: make(map[string]*sql.Stmt),
+	}
+}
+
+// Close closes every prepared statement cached by r. Callers that discard a
+// MySQLUserRepo before the underlying DBPool should call Close to avoid
+// leaking the cached statements.
+func (r *MySQLUserRepo) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+	for query, stmt := range r.stmts {
+		if err := stmt.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close statement for %q: %w", query, err)
+		}
+	}
+	r.stmts = make(map[string]*sql.Stmt)
+	return firstErr
+}
+
+func (r *MySQLUserRepo) prepare(ctx context.Context, query string) (*sql.Stmt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stmt, ok := r.stmts[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := r.pool.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	r.stmts[query] = stmt
+	return stmt, nil
+}
+
+func (r *MySQLUserRepo) GetUserByID(id int) (*models.User, error) {
+	return r.pool.GetUserByID(context.Background(), id)
+}
+
+func (r *MySQLUserRepo) GetOrdersByUserID(userID int) ([]models.Order, error) {
+	return r.pool.GetOrdersByUserID(context.Background(), userID)
+}
+
+func (r *MySQLUserRepo) Insert(user models.User) error {
+	ctx := context.Background()
+	stmt, err := r.prepare(ctx, "INSERT INTO users (username, email) VALUES (?, ?)")
+	if err != nil {
+		return err
+	}
+	if _, err := stmt.ExecContext(ctx, user.Username, user.Email); err != nil {
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+	return nil
+}
+
+func (r *MySQLUserRepo) Update(id int, user models.User) error {
+	ctx := context.Background()
+	stmt, err := r.prepare(ctx, "UPDATE users SET username = ?, email = ? WHERE id = ?")
+	if err != nil {
+		return err
+	}
+	if _, err := stmt.ExecContext(ctx, user.Username, user.Email, id); err != nil {
+		return fmt.Errorf("failed to update user %d: %w", id, err)
+	}
+	return nil
+}
+
+func (r *MySQLUserRepo) Delete(id int) error {
+	ctx := context.Background()
+	stmt, err := r.prepare(ctx, "DELETE FROM users WHERE id = ?")
+	if err != nil {
+		return err
+	}
+	if _, err := stmt.ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete user %d: %w", id, err)
+	}
+	return nil
+}
+
+// Upsert writes user in a single statement instead of a GetUserByID-then-
+// Insert/Update round trip, which removes the race between reading the
+// existing row and deciding whether to insert or update it.
+func (r *MySQLUserRepo) Upsert(user models.User) error {
+	ctx := context.Background()
+	return r.pool.InTx(ctx, nil, func(tx *sql.Tx) error {
+		query := `INSERT INTO users (id, username, email) VALUES (?, ?, ?)
+				  ON DUPLICATE KEY UPDATE username = VALUES(username), email = VALUES(email)`
+		if _, err := tx.ExecContext(ctx, query, user.ID, user.Username, user.Email); err != nil {
+			return fmt.Errorf("failed to upsert user %d: %w", user.ID, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,32 @@
+package db_connection_test
+
+import (
+	"testing"
+
+	"github.com/mark1002/practice/db_connection"
+	"github.com/mark1002/practice/db_connection/dbtest"
+	"github.com/mark1002/practice/mockgen"
+	"github.com/mark1002/practice/models"
+)
+
+func TestMySQLUserRepoUpsert(t *testing.T) {
+	pool, ctrl := dbtest.NewInMemPool(t)
+	ctrl.ExpectExec(`(?s)INSERT INTO users .* ON DUPLICATE KEY UPDATE`, dbtest.Result{RowsAffectedN: 1})
+
+	var repo mockgen.IUserRepo = db_connection.NewMySQLUserRepo(pool)
+
+	if err := repo.Upsert(models.User{ID: 1, Username: "alice", Email: "alice@example.com"}); err != nil {
+		t.Fatalf("Upsert returned unexpected error: %v", err)
+	}
+}
+
+func TestMySQLUserRepoDelete(t *testing.T) {
+	pool, ctrl := dbtest.NewInMemPool(t)
+	ctrl.ExpectExec("DELETE FROM users", dbtest.Result{RowsAffectedN: 1})
+
+	repo := db_connection.NewMySQLUserRepo(pool)
+
+	if err := repo.Delete(1); err != nil {
+		t.Fatalf("Delete returned unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package db_connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error numbers that are safe to retry.
+const (
+	mysqlErrDeadlock        = 1213
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrServerGone      = 2006
+	mysqlErrLostConnection  = 2013
+)
+
+// RetryPolicy configures how Do retries a unit of work against the database.
+type RetryPolicy struct {
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	MaxAttempts    int
+	// Classify decides whether err is retryable and, if so, how long to
+	// sleep before the next attempt. Defaults to DefaultClassifier.
+	Classify func(err error) (retry bool, sleep time.Duration)
+}
+
+// DefaultRetryPolicy retries transient MySQL errors a handful of times with
+// exponential backoff, matching the retry loop gax-style clients use around
+// Spanner/MySQL calls.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		MaxAttempts:    4,
+		Classify:       DefaultClassifier,
+	}
+}
+
+// DefaultClassifier recognizes the MySQL errors that are safe to retry:
+// deadlocks, lock wait timeouts, a server/connection gone away, and
+// database/sql's own driver.ErrBadConn. context.Canceled and
+// context.DeadlineExceeded are never retried, since the caller has already
+// given up.
+func DefaultClassifier(err error) (bool, time.Duration) {
+	if err == nil {
+		return false, 0
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false, 0
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true, 0
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case mysqlErrDeadlock, mysqlErrLockWaitTimeout, mysqlErrServerGone, mysqlErrLostConnection:
+			return true, 0
+		}
+	}
+
+	return false, 0
+}
+
+// Do runs fn, retrying it according to policy when fn returns a retryable
+// error. Zero-value fields in policy fall back to DefaultRetryPolicy's
+// values, so callers can override just the pieces they care about.
+func (p *DBPool) Do(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	policy = withPolicyDefaults(policy)
+
+	var lastErr error
+	backoff := policy.InitialBackoff
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		lastErr = fn(ctx)
+		if lastErr == nil {
+			return nil
+		}
+
+		retry, sleep := policy.Classify(lastErr)
+		if !retry || attempt == policy.MaxAttempts {
+			return lastErr
+		}
+
+		if sleep <= 0 {
+			sleep = backoff + time.Duration(rand.Int63n(int64(backoff)+1))
+			backoff = time.Duration(float64(backoff) * policy.Multiplier)
+			if backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+		}
+
+		log.Printf("Retrying after attempt %d/%d failed: %v", attempt, policy.MaxAttempts, lastErr)
+		select {
+		case <-time.After(sleep):
+		case <-ctx.Done():
+			return fmt.Errorf("retry canceled after attempt %d: %w", attempt, ctx.Err())
+		}
+	}
+
+	return lastErr
+}
+
+func withPolicyDefaults(policy RetryPolicy) RetryPolicy {
+	defaults := DefaultRetryPolicy()
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaults.InitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaults.MaxBackoff
+	}
+	if policy.Multiplier <= 0 {
+		policy.Multiplier = defaults.Multiplier
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaults.MaxAttempts
+	}
+	if policy.Classify == nil {
+		policy.Classify = defaults.Classify
+	}
+	return policy
+}
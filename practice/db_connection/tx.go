@@ -0,0 +1,59 @@
+package db_connection
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// InTx begins a transaction, invokes fn, and commits or rolls back based on
+// its outcome (including on panic, where the panic is re-raised after the
+// rollback). The whole begin/fn/commit cycle is retried through Do using
+// DefaultRetryPolicy, so a deadlock (MySQL error 1213) or lock wait timeout
+// (1205) re-runs fn from scratch, mirroring how the Spanner client retries
+// aborted transactions.
+func (p *DBPool) InTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	return p.Do(ctx, DefaultRetryPolicy(), func(ctx context.Context) (err error) {
+		tx, err := p.DB.BeginTx(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				tx.Rollback()
+				panic(r)
+			}
+		}()
+
+		if err := fn(tx); err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				return fmt.Errorf("tx failed: %v, rollback failed: %w", err, rbErr)
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	})
+}
+
+// Savepoint creates a named savepoint within tx so a later block can be
+// rolled back with RollbackTo without discarding the whole transaction.
+func Savepoint(tx *sql.Tx, name string) error {
+	if _, err := tx.Exec(fmt.Sprintf("SAVEPOINT %s", name)); err != nil {
+		return fmt.Errorf("failed to create savepoint %s: %w", name, err)
+	}
+	return nil
+}
+
+// RollbackTo rolls tx back to the named savepoint created by Savepoint,
+// leaving the rest of the transaction intact.
+func RollbackTo(tx *sql.Tx, name string) error {
+	if _, err := tx.Exec(fmt.Sprintf("ROLLBACK TO SAVEPOINT %s", name)); err != nil {
+		return fmt.Errorf("failed to rollback to savepoint %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package db_connection_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/mark1002/practice/db_connection/dbtest"
+)
+
+func TestInTxRetriesOnDeadlock(t *testing.T) {
+	pool, ctrl := dbtest.NewInMemPool(t)
+	ctrl.ExpectExec("INSERT INTO users", dbtest.Result{LastInsertID: 1, RowsAffectedN: 1})
+	ctrl.FailNextWith(&mysql.MySQLError{Number: 1213, Message: "Deadlock found"})
+
+	attempts := 0
+	err := pool.InTx(context.Background(), nil, func(tx *sql.Tx) error {
+		attempts++
+		_, err := tx.Exec("INSERT INTO users (username, email) VALUES (?, ?)", "alice", "alice@example.com")
+		return err
+	})
+
+	if err != nil {
+		t.Fatalf("InTx returned unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected fn to run twice, ran %d times", attempts)
+	}
+}
@@ -0,0 +1,12 @@
+package mockgen
+
+import "github.com/mark1002/practice/models"
+
+type IUserRepo interface {
+	GetUserByID(id int) (*models.User, error)
+	GetOrdersByUserID(userID int) ([]models.Order, error)
+	Insert(user models.User) error
+	Update(id int, user models.User) error
+	Delete(id int) error
+	Upsert(user models.User) error
+}
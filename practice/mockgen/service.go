@@ -1,6 +1,10 @@
 package mockgen
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/mark1002/practice/models"
+)
 
 type UserService struct {
 	repo IUserRepo
@@ -8,22 +12,14 @@ type UserService struct {
 
 var invalidUserIDError = fmt.Errorf("invalid user id")
 
-func (u *UserService) Upsert(user User) error {
+func (u *UserService) Upsert(user models.User) error {
 	if user.ID <= 0 {
 		return invalidUserIDError
 	}
-	existingUser, err := u.repo.GetUserByID(user.ID)
-	if err != nil {
-		return err
-	}
-	if existingUser == nil {
-		return u.repo.Insert(user)
-	}
-
-	return u.repo.Update(user.ID, user)
+	return u.repo.Upsert(user)
 }
 
-func (u *UserService) GetUserByID(id int) (*User, error) {
+func (u *UserService) GetUserByID(id int) (*models.User, error) {
 	return u.repo.GetUserByID(id)
 }
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/mark1002/practice/models"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -14,24 +15,15 @@ func TestUpsertUser(t *testing.T) {
 	// Given
 	tests := []struct {
 		name                 string
-		user                 User
+		user                 models.User
 		specifyFunctionCalls func(mock *MockIUserRepo)
 		expectedError        error
 	}{
 		{
-			user: User{ID: 1, Name: "User 1"},
-			name: "Should insert",
+			user: models.User{ID: 1, Username: "User 1"},
+			name: "Should upsert",
 			specifyFunctionCalls: func(mockRepo *MockIUserRepo) {
-				mockRepo.EXPECT().GetUserByID(1).Return(nil, nil).Times(1)
-				mockRepo.EXPECT().Insert(User{ID: 1, Name: "User 1"}).Return(nil).Times(1)
-			},
-		},
-		{
-			name: "User existed - Should update",
-			user: User{ID: 1, Name: "New User Name"},
-			specifyFunctionCalls: func(mockRepo *MockIUserRepo) {
-				mockRepo.EXPECT().GetUserByID(1).Return(&User{ID: 1, Name: "User 1"}, nil).Times(1)
-				mockRepo.EXPECT().Update(1, User{ID: 1, Name: "New User Name"}).Return(nil).Times(1)
+				mockRepo.EXPECT().Upsert(models.User{ID: 1, Username: "User 1"}).Return(nil).Times(1)
 			},
 		},
 		{
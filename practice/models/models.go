@@ -0,0 +1,23 @@
+// Package models holds the data types shared between db_connection's
+// repositories and mockgen's service layer, so both sides of that boundary
+// agree on a single User/Order shape.
+package models
+
+type User struct {
+	ID        int    `json:"id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type Order struct {
+	ID          int     `json:"id"`
+	UserID      int     `json:"user_id"`
+	ProductName string  `json:"product_name"`
+	Quantity    int     `json:"quantity"`
+	Price       float64 `json:"price"`
+	Status      string  `json:"status"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}